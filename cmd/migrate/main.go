@@ -1,19 +1,29 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
 
+	"github.com/phuocnguyen/user-api/pkg/config"
 	"github.com/phuocnguyen/user-api/pkg/db"
+	"github.com/phuocnguyen/user-api/pkg/env"
 )
 
 func main() {
 	var (
-		up    = flag.Bool("up", false, "Run all pending migrations")
-		down  = flag.Bool("down", false, "Rollback the last migration")
-		force = flag.Bool("force", false, "Force run migrations (ignores migration history)")
-		help  = flag.Bool("help", false, "Show this help message")
+		up         = flag.Bool("up", false, "Run all pending migrations")
+		down       = flag.Bool("down", false, "Rollback the most recently applied migration")
+		to         = flag.Int("to", -1, "Migrate up or down to a specific version")
+		status     = flag.Bool("status", false, "Show which migrations are pending vs. applied")
+		force      = flag.Bool("force", false, "Ask for confirmation, then run pending migrations (migration history is still honoured)")
+		silent     = flag.Bool("silent", false, "Suppress progress output")
+		noProgress = flag.Bool("no-progress", false, "Disable the progress bar")
+		help       = flag.Bool("help", false, "Show this help message")
 	)
 	flag.Parse()
 
@@ -22,54 +32,106 @@ func main() {
 		return
 	}
 
+	// Load .env file
+	if err := env.LoadEnv(".env"); err != nil {
+		log.Printf("Warning: Failed to load .env file: %v", err)
+	}
+
+	cfg, err := config.Load("config.toml", os.Getenv("APP_ENV"))
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
 	// Initialize database connection
-	if err := db.InitPostgres(); err != nil {
+	if err := db.InitPostgres(cfg.DB); err != nil {
 		log.Fatalf("Failed to initialize database: %v", err)
 	}
 
+	// SIGINT/SIGTERM cancels ctx between migrations, so an interrupted run
+	// still finishes the current file's transaction before stopping.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	reporter := newReporter(*silent, *noProgress)
+
 	switch {
+	case *to >= 0:
+		runMigrateTo(ctx, *to, reporter)
 	case *up:
-		runMigrationsUp(*force)
+		runMigrationsUp(ctx, *force, reporter)
 	case *down:
 		runMigrationsDown()
+	case *status:
+		runStatus()
 	default:
 		fmt.Println("No action specified. Use -help for usage information.")
 		showHelp()
 	}
 }
 
-func runMigrationsUp(force bool) {
+func runMigrationsUp(ctx context.Context, force bool, reporter db.ProgressReporter) {
 	fmt.Println("Running migrations...")
-	
+
 	if force {
 		fmt.Println("⚠️  FORCE MODE: This will run all migrations regardless of history!")
 		fmt.Println("Are you sure? This could cause data loss. (y/N): ")
-		
+
 		var response string
 		fmt.Scanln(&response)
 		if response != "y" && response != "Y" {
 			fmt.Println("Migration cancelled.")
 			return
 		}
-		
+
 		// For force mode, you might want to implement a ForceRunMigrations function
 		// For now, we'll use the regular function
 	}
-	
-	if err := db.RunMigrations(); err != nil {
+
+	if err := db.RunMigrations(ctx, reporter); err != nil {
 		log.Fatalf("Failed to run migrations: %v", err)
 	}
-	
+
 	fmt.Println("✅ Migrations completed successfully!")
 }
 
 func runMigrationsDown() {
-	fmt.Println("⚠️  Rollback functionality not implemented yet.")
-	fmt.Println("This would rollback the last migration.")
-	fmt.Println("For now, you can manually rollback by:")
-	fmt.Println("1. Connecting to your database")
-	fmt.Println("2. Running the reverse SQL commands")
-	fmt.Println("3. Removing the entry from the migrations table")
+	fmt.Println("Rolling back the last migration...")
+
+	if err := db.RunMigrationsDown(); err != nil {
+		log.Fatalf("Failed to roll back migration: %v", err)
+	}
+
+	fmt.Println("✅ Rollback completed successfully!")
+}
+
+func runMigrateTo(ctx context.Context, version int, reporter db.ProgressReporter) {
+	fmt.Printf("Migrating to version %d...\n", version)
+
+	if err := db.MigrateTo(ctx, version, reporter); err != nil {
+		log.Fatalf("Failed to migrate to version %d: %v", version, err)
+	}
+
+	fmt.Println("✅ Migration completed successfully!")
+}
+
+func runStatus() {
+	statuses, err := db.Status()
+	if err != nil {
+		log.Fatalf("Failed to get migration status: %v", err)
+	}
+
+	if len(statuses) == 0 {
+		fmt.Println("No migrations found.")
+		return
+	}
+
+	for _, s := range statuses {
+		state := "pending"
+		if s.Applied {
+			state = "applied"
+		}
+		fmt.Printf("  %03d  %-40s  %s\n", s.Version, s.Name, state)
+	}
 }
 
 func showHelp() {
@@ -77,16 +139,22 @@ func showHelp() {
 	fmt.Println("Usage: go run cmd/migrate/main.go [OPTIONS]")
 	fmt.Println("")
 	fmt.Println("Options:")
-	fmt.Println("  -up     Run all pending migrations")
-	fmt.Println("  -down   Rollback the last migration (not implemented yet)")
-	fmt.Println("  -force  Force run all migrations (dangerous!)")
-	fmt.Println("  -help   Show this help message")
+	fmt.Println("  -up           Run all pending migrations")
+	fmt.Println("  -down         Rollback the most recently applied migration")
+	fmt.Println("  -to N         Migrate up or down to a specific version")
+	fmt.Println("  -status       Show which migrations are pending vs. applied")
+	fmt.Println("  -force        Ask for confirmation, then run pending migrations")
+	fmt.Println("  -silent       Suppress progress output")
+	fmt.Println("  -no-progress  Disable the progress bar")
+	fmt.Println("  -help         Show this help message")
 	fmt.Println("")
 	fmt.Println("Examples:")
 	fmt.Println("  go run cmd/migrate/main.go -up")
 	fmt.Println("  go run cmd/migrate/main.go -down")
+	fmt.Println("  go run cmd/migrate/main.go -to 3")
+	fmt.Println("  go run cmd/migrate/main.go -status")
 	fmt.Println("  go run cmd/migrate/main.go -help")
 	fmt.Println("")
 	fmt.Println("Environment Variables:")
 	fmt.Println("  DATABASE_URL - PostgreSQL connection string")
-}
\ No newline at end of file
+}
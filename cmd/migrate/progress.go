@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/cheggaaa/pb/v3"
+	"golang.org/x/term"
+
+	"github.com/phuocnguyen/user-api/pkg/db"
+)
+
+// barReporter drives a terminal progress bar showing "migration N of M"
+// with elapsed and estimated remaining time.
+type barReporter struct {
+	bar *pb.ProgressBar
+}
+
+func (r *barReporter) Step(current, total int, name string) {
+	if r.bar == nil {
+		tmpl := `{{string . "prefix"}}{{counters . }} {{bar . }} {{percent . }} elapsed {{etime . }} eta {{rtime . }}`
+		r.bar = pb.ProgressBarTemplate(tmpl).Start(total)
+	}
+
+	r.bar.Set("prefix", fmt.Sprintf("[%s] ", name))
+	r.bar.SetCurrent(int64(current))
+
+	if current == total {
+		r.bar.Finish()
+	}
+}
+
+// newReporter returns a terminal progress bar reporter, or nil when
+// progress shouldn't be shown: -silent/-no-progress was passed, or stderr
+// isn't a terminal (e.g. CI logs).
+func newReporter(silent, noProgress bool) db.ProgressReporter {
+	if silent || noProgress {
+		return nil
+	}
+	if !term.IsTerminal(int(os.Stderr.Fd())) {
+		return nil
+	}
+	return &barReporter{}
+}
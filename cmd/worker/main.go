@@ -0,0 +1,25 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/phuocnguyen/user-api/internal/app"
+)
+
+func main() {
+	a, err := app.Bootstrap()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log.Println("Worker starting, polling for jobs...")
+	a.RunWorker(ctx)
+	log.Println("Worker stopped")
+}
@@ -0,0 +1,117 @@
+// Package app holds the shared bootstrap and runtime used by the api and
+// worker commands: loading config, connecting to the database, running
+// migrations, and wiring up repositories and handlers.
+package app
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/phuocnguyen/user-api/internal/job"
+	"github.com/phuocnguyen/user-api/internal/user"
+	"github.com/phuocnguyen/user-api/pkg/config"
+	"github.com/phuocnguyen/user-api/pkg/db"
+	"github.com/phuocnguyen/user-api/pkg/env"
+	httpxecho "github.com/phuocnguyen/user-api/pkg/httpx-echo"
+)
+
+// App holds everything bootstrapped from config that the api and worker
+// commands both need: the loaded config, repositories, and handlers.
+type App struct {
+	Config      *config.Config
+	UserHandler *user.Handler
+	JobHandler  *job.Handler
+	JobRepo     *job.Repository
+	JobRegistry *job.Registry
+	Worker      *job.Worker
+}
+
+// Bootstrap loads the .env file and typed config, initializes the database,
+// runs migrations if auto-migrate is enabled, seeds the database, and wires
+// up the repositories and handlers shared by the api and worker commands.
+func Bootstrap() (*App, error) {
+	if err := env.LoadEnv(".env"); err != nil {
+		log.Printf("Warning: Failed to load .env file: %v", err)
+	}
+
+	cfg, err := config.Load("config.toml", os.Getenv("APP_ENV"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.JWT.Secret != "" && os.Getenv("JWT_SECRET") == "" {
+		os.Setenv("JWT_SECRET", cfg.JWT.Secret)
+	}
+
+	if err := db.InitPostgres(cfg.DB); err != nil {
+		return nil, fmt.Errorf("failed to initialize database: %w", err)
+	}
+
+	if cfg.Features.AutoMigrate {
+		log.Println("Auto-migration enabled, running migrations...")
+		if err := db.RunMigrations(context.Background(), nil); err != nil {
+			return nil, fmt.Errorf("failed to run migrations: %w", err)
+		}
+	} else {
+		log.Println("Auto-migration disabled. Use 'go run cmd/migrate/main.go -up' to run migrations manually.")
+	}
+
+	if err := db.SeedDatabase(); err != nil {
+		log.Printf("Warning: Failed to seed database: %v", err)
+	}
+
+	jobRepo := job.NewRepository(db.DB)
+	jobRegistry := job.NewRegistry()
+	job.RegisterDefaultHandlers(jobRegistry)
+	worker := job.NewWorker(jobRepo, jobRegistry, 2*time.Second)
+
+	userRepo := user.NewRepository(db.DB)
+	userHandler := user.NewHandler(userRepo, jobRepo)
+	jobHandler := job.NewHandler(jobRepo)
+
+	return &App{
+		Config:      cfg,
+		UserHandler: userHandler,
+		JobHandler:  jobHandler,
+		JobRepo:     jobRepo,
+		JobRegistry: jobRegistry,
+		Worker:      worker,
+	}, nil
+}
+
+// Run starts the HTTP server and blocks until ctx is cancelled, then shuts
+// the server down gracefully.
+func (a *App) Run(ctx context.Context) error {
+	e := echo.New()
+	httpxecho.RegisterRoutes(e, a.UserHandler, a.JobHandler)
+
+	serveErr := make(chan error, 1)
+	go func() {
+		if err := e.Start(fmt.Sprintf(":%d", a.Config.HTTP.Port)); err != nil && err != http.ErrServerClosed {
+			serveErr <- err
+			return
+		}
+		serveErr <- nil
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return e.Shutdown(shutdownCtx)
+}
+
+// RunWorker starts the background job worker and blocks until ctx is
+// cancelled.
+func (a *App) RunWorker(ctx context.Context) {
+	a.Worker.Run(ctx)
+}
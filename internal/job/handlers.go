@@ -0,0 +1,51 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+)
+
+// Built-in job types.
+const (
+	TypeSendWelcomeEmail = "send_welcome_email"
+	TypeUserExportCSV    = "user_export_csv"
+)
+
+// RegisterDefaultHandlers wires the built-in job types into the registry.
+func RegisterDefaultHandlers(registry *Registry) {
+	registry.Register(TypeSendWelcomeEmail, handleSendWelcomeEmail)
+	registry.Register(TypeUserExportCSV, handleUserExportCSV)
+}
+
+type sendWelcomeEmailPayload struct {
+	UserID string `json:"user_id"`
+	Email  string `json:"email"`
+}
+
+func handleSendWelcomeEmail(ctx context.Context, payload json.RawMessage) error {
+	var p sendWelcomeEmailPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return fmt.Errorf("invalid %s payload: %w", TypeSendWelcomeEmail, err)
+	}
+
+	// TODO: integrate with a real mail provider; logging stands in for now.
+	log.Printf("job: sending welcome email to %s (user %s)", p.Email, p.UserID)
+	return nil
+}
+
+type userExportCSVPayload struct {
+	RequestedBy string `json:"requested_by"`
+}
+
+func handleUserExportCSV(ctx context.Context, payload json.RawMessage) error {
+	var p userExportCSVPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return fmt.Errorf("invalid %s payload: %w", TypeUserExportCSV, err)
+	}
+
+	// TODO: stream users to CSV and upload/store the result.
+	log.Printf("job: exporting users to CSV for request %s", p.RequestedBy)
+	return nil
+}
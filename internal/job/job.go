@@ -0,0 +1,54 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Status is the lifecycle state of a queued job.
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+)
+
+// Job is a single unit of async work persisted in the jobs table.
+type Job struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	Payload   json.RawMessage `json:"payload"`
+	Status    Status          `json:"status"`
+	Attempts  int             `json:"attempts"`
+	RunAfter  time.Time       `json:"run_after"`
+	LastError *string         `json:"last_error,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// HandlerFunc processes a single job's payload. A returned error marks the
+// job for retry, or for permanent failure once MaxAttempts is reached.
+type HandlerFunc func(ctx context.Context, payload json.RawMessage) error
+
+// Registry maps job type strings to the handler that processes them.
+type Registry struct {
+	handlers map[string]HandlerFunc
+}
+
+// NewRegistry returns an empty job Registry.
+func NewRegistry() *Registry {
+	return &Registry{handlers: make(map[string]HandlerFunc)}
+}
+
+// Register associates a job type with the handler that processes it.
+func (r *Registry) Register(jobType string, handler HandlerFunc) {
+	r.handlers[jobType] = handler
+}
+
+func (r *Registry) lookup(jobType string) (HandlerFunc, bool) {
+	handler, ok := r.handlers[jobType]
+	return handler, ok
+}
@@ -0,0 +1,131 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Repository struct {
+	db *pgxpool.Pool
+}
+
+func NewRepository(db *pgxpool.Pool) *Repository {
+	return &Repository{db: db}
+}
+
+// Enqueue inserts a new pending job of the given type with a JSON-encoded
+// payload, returning the generated job id.
+func (r *Repository) Enqueue(ctx context.Context, jobType string, payload interface{}) (string, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal job payload: %w", err)
+	}
+
+	id := uuid.NewString()
+	query := `INSERT INTO jobs (id, type, payload) VALUES ($1, $2, $3)`
+	if _, err := r.db.Exec(ctx, query, id, jobType, body); err != nil {
+		return "", fmt.Errorf("failed to enqueue job: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetByID fetches a single job by id.
+func (r *Repository) GetByID(ctx context.Context, id string) (*Job, error) {
+	query := `
+		SELECT id, type, payload, status, attempts, run_after, last_error, created_at, updated_at
+		FROM jobs WHERE id = $1
+	`
+
+	var j Job
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&j.ID, &j.Type, &j.Payload, &j.Status, &j.Attempts, &j.RunAfter, &j.LastError, &j.CreatedAt, &j.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+
+	return &j, nil
+}
+
+// ClaimNext locks and returns the next runnable job using
+// SELECT ... FOR UPDATE SKIP LOCKED, so multiple workers can poll
+// concurrently without contending on the same row. It returns pgx.ErrNoRows
+// when nothing is runnable.
+func (r *Repository) ClaimNext(ctx context.Context) (*Job, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin claim transaction: %w", err)
+	}
+
+	committed := false
+	defer func() {
+		if !committed {
+			tx.Rollback(ctx)
+		}
+	}()
+
+	query := `
+		SELECT id, type, payload, status, attempts, run_after, last_error, created_at, updated_at
+		FROM jobs
+		WHERE status = 'pending' AND run_after <= NOW()
+		ORDER BY run_after
+		FOR UPDATE SKIP LOCKED
+		LIMIT 1
+	`
+
+	var j Job
+	err = tx.QueryRow(ctx, query).Scan(
+		&j.ID, &j.Type, &j.Payload, &j.Status, &j.Attempts, &j.RunAfter, &j.LastError, &j.CreatedAt, &j.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.Exec(ctx, "UPDATE jobs SET status = 'running', updated_at = NOW() WHERE id = $1", j.ID); err != nil {
+		return nil, fmt.Errorf("failed to mark job running: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit job claim: %w", err)
+	}
+	committed = true
+
+	j.Status = StatusRunning
+	return &j, nil
+}
+
+// MarkCompleted records a job as successfully finished.
+func (r *Repository) MarkCompleted(ctx context.Context, id string) error {
+	query := `UPDATE jobs SET status = $2, updated_at = NOW() WHERE id = $1`
+	if _, err := r.db.Exec(ctx, query, id, StatusCompleted); err != nil {
+		return fmt.Errorf("failed to mark job completed: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed records a failed attempt. If permanent is false the job is
+// rescheduled at runAfter for another try; otherwise it is marked
+// permanently failed.
+func (r *Repository) MarkFailed(ctx context.Context, id string, attempts int, runAfter time.Time, permanent bool, lastErr string) error {
+	status := StatusPending
+	if permanent {
+		status = StatusFailed
+	}
+
+	query := `
+		UPDATE jobs
+		SET status = $2, attempts = $3, run_after = $4, last_error = $5, updated_at = NOW()
+		WHERE id = $1
+	`
+	if _, err := r.db.Exec(ctx, query, id, status, attempts, runAfter, lastErr); err != nil {
+		return fmt.Errorf("failed to mark job failed: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// MaxAttempts is how many times in total a job is attempted (the first
+	// run included) before being marked permanently failed.
+	MaxAttempts = 5
+
+	baseBackoff = 2 * time.Second
+)
+
+// Worker polls the jobs table and dispatches runnable jobs to their
+// registered handler.
+type Worker struct {
+	repo     *Repository
+	registry *Registry
+	interval time.Duration
+}
+
+// NewWorker builds a Worker that polls every interval for runnable jobs.
+func NewWorker(repo *Repository, registry *Registry, interval time.Duration) *Worker {
+	return &Worker{repo: repo, registry: registry, interval: interval}
+}
+
+// Run polls for jobs on a fixed interval until ctx is cancelled.
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.drain(ctx)
+		}
+	}
+}
+
+// drain processes runnable jobs until none are left or ctx is cancelled,
+// so a large backlog doesn't delay shutdown on SIGINT/SIGTERM.
+func (w *Worker) drain(ctx context.Context) {
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		j, err := w.repo.ClaimNext(ctx)
+		if err != nil {
+			if err != pgx.ErrNoRows {
+				log.Printf("job: failed to claim next job: %v", err)
+			}
+			return
+		}
+
+		w.process(ctx, j)
+	}
+}
+
+func (w *Worker) process(ctx context.Context, j *Job) {
+	handler, ok := w.registry.lookup(j.Type)
+	if !ok {
+		w.fail(ctx, j, fmt.Errorf("no handler registered for job type %q", j.Type))
+		return
+	}
+
+	if err := handler(ctx, j.Payload); err != nil {
+		w.fail(ctx, j, err)
+		return
+	}
+
+	if err := w.repo.MarkCompleted(ctx, j.ID); err != nil {
+		log.Printf("job: failed to mark job %s completed: %v", j.ID, err)
+	}
+}
+
+func (w *Worker) fail(ctx context.Context, j *Job, cause error) {
+	attempts := j.Attempts + 1
+	permanent := attempts >= MaxAttempts
+	backoff := time.Duration(math.Pow(2, float64(attempts))) * baseBackoff
+
+	if err := w.repo.MarkFailed(ctx, j.ID, attempts, time.Now().Add(backoff), permanent, cause.Error()); err != nil {
+		log.Printf("job: failed to record failure for job %s: %v", j.ID, err)
+	}
+}
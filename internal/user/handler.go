@@ -2,10 +2,16 @@ package user
 
 import (
 	"encoding/json"
+	"fmt"
+	"log"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
+	"github.com/phuocnguyen/user-api/internal/job"
+	"github.com/phuocnguyen/user-api/pkg/auth"
 )
 
 type CreateUserRequest struct {
@@ -15,10 +21,29 @@ type CreateUserRequest struct {
 }
 
 type UserResponse struct {
-	ID       string    `json:"id"`
-	Username string `json:"username"`
-	Email    string `json:"email"`
-	Age      int    `json:"age"`
+	ID        string    `json:"id"`
+	Username  string    `json:"username"`
+	Email     string    `json:"email"`
+	Age       int       `json:"age"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type RegisterRequest struct {
+	Username string `json:"username" validate:"required,min=3,max=32"`
+	Email    string `json:"email" validate:"required,email"`
+	Age      int    `json:"age" validate:"required,min=0,max=120"`
+	Password string `json:"password" validate:"required,min=8,max=72"`
+}
+
+type LoginRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
+type AuthResponse struct {
+	Token    string       `json:"token"`
+	APIToken string       `json:"api_token"`
+	User     UserResponse `json:"user"`
 }
 
 func decodeStrictJSON(c echo.Context, v interface{}) error {
@@ -32,10 +57,17 @@ var validate = validator.New()
 
 type Handler struct {
 	repo *Repository
+	jobs *job.Repository
+}
+
+func NewHandler(repo *Repository, jobs *job.Repository) *Handler {
+	return &Handler{repo: repo, jobs: jobs}
 }
 
-func NewHandler(repo *Repository) *Handler {
-	return &Handler{repo: repo}
+// LookupUserIDByAPIToken resolves an X-API-Key header value to a user id,
+// for use as an auth.APITokenLookup.
+func (h *Handler) LookupUserIDByAPIToken(token string) (string, error) {
+	return h.repo.GetUserIDByAPIToken(token)
 }
 
 func (h *Handler) CreateUser(c echo.Context) error {
@@ -53,9 +85,81 @@ func (h *Handler) CreateUser(c echo.Context) error {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to create user"})
 	}
 
+	if h.jobs != nil {
+		payload := map[string]string{"user_id": user.ID, "email": user.Email}
+		if _, err := h.jobs.Enqueue(c.Request().Context(), job.TypeSendWelcomeEmail, payload); err != nil {
+			log.Printf("failed to enqueue welcome email for user %s: %v", user.ID, err)
+		}
+	}
+
 	return c.JSON(http.StatusCreated, user)
 }
 
+func (h *Handler) Register(c echo.Context) error {
+	var req RegisterRequest
+	if err := decodeStrictJSON(c, &req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	passwordHash, err := auth.HashPassword(req.Password)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to register user"})
+	}
+
+	apiToken, err := auth.GenerateAPIToken()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to register user"})
+	}
+
+	user, err := h.repo.CreateWithAuth(req, passwordHash, apiToken)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to register user"})
+	}
+
+	token, err := auth.GenerateJWT(user.ID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to register user"})
+	}
+
+	return c.JSON(http.StatusCreated, AuthResponse{Token: token, APIToken: apiToken, User: *user})
+}
+
+func (h *Handler) Login(c echo.Context) error {
+	var req LoginRequest
+	if err := decodeStrictJSON(c, &req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	authUser, err := h.repo.GetAuthByEmail(req.Email)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid credentials"})
+	}
+
+	if err := auth.CheckPassword(authUser.PasswordHash, req.Password); err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid credentials"})
+	}
+
+	user, err := h.repo.GetByID(authUser.ID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to log in"})
+	}
+
+	token, err := auth.GenerateJWT(authUser.ID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to log in"})
+	}
+
+	return c.JSON(http.StatusOK, AuthResponse{Token: token, APIToken: authUser.APIToken, User: *user})
+}
+
 func (h *Handler) GetUser(c echo.Context) error {
 	id := c.Param("id")
 	if id == "" {
@@ -70,13 +174,108 @@ func (h *Handler) GetUser(c echo.Context) error {
 	return c.JSON(http.StatusOK, user)
 }
 
+// PageInfo describes the page of results returned by GetUsers.
+type PageInfo struct {
+	Limit      int    `json:"limit"`
+	Offset     int    `json:"offset"`
+	Total      *int   `json:"total,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// UsersEnvelope wraps a page of users with its pagination metadata.
+type UsersEnvelope struct {
+	Data []UserResponse `json:"data"`
+	Page PageInfo       `json:"page"`
+}
+
+// maxListLimit caps page size so a single request can't force an
+// unbounded LIMIT against Postgres.
+const maxListLimit = 100
+
+func parseListParams(c echo.Context) (ListParams, error) {
+	params := ListParams{
+		Limit:  20,
+		Offset: 0,
+		Cursor: c.QueryParam("cursor"),
+		SortBy: c.QueryParam("sort"),
+		Order:  c.QueryParam("order"),
+	}
+
+	if v := c.QueryParam("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit <= 0 {
+			return params, fmt.Errorf("limit must be a positive integer")
+		}
+		if limit > maxListLimit {
+			limit = maxListLimit
+		}
+		params.Limit = limit
+	}
+
+	if v := c.QueryParam("offset"); v != "" {
+		offset, err := strconv.Atoi(v)
+		if err != nil || offset < 0 {
+			return params, fmt.Errorf("offset must be a non-negative integer")
+		}
+		params.Offset = offset
+	}
+
+	if v := c.QueryParam("email_like"); v != "" {
+		params.EmailLike = v
+	}
+
+	if v := c.QueryParam("min_age"); v != "" {
+		minAge, err := strconv.Atoi(v)
+		if err != nil {
+			return params, fmt.Errorf("min_age must be an integer")
+		}
+		params.MinAge = &minAge
+	}
+
+	if v := c.QueryParam("max_age"); v != "" {
+		maxAge, err := strconv.Atoi(v)
+		if err != nil {
+			return params, fmt.Errorf("max_age must be an integer")
+		}
+		params.MaxAge = &maxAge
+	}
+
+	if v := c.QueryParam("with_total"); v != "" {
+		withTotal, err := strconv.ParseBool(v)
+		if err != nil {
+			return params, fmt.Errorf("with_total must be a boolean")
+		}
+		params.WithTotal = withTotal
+	}
+
+	return params, nil
+}
+
 func (h *Handler) GetUsers(c echo.Context) error {
-	users, err := h.repo.GetAll()
+	params, err := parseListParams(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	result, err := h.repo.List(params)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to get users"})
 	}
 
-	return c.JSON(http.StatusOK, users)
+	data := result.Users
+	if data == nil {
+		data = []UserResponse{}
+	}
+
+	return c.JSON(http.StatusOK, UsersEnvelope{
+		Data: data,
+		Page: PageInfo{
+			Limit:      params.Limit,
+			Offset:     params.Offset,
+			Total:      result.Total,
+			NextCursor: result.NextCursor,
+		},
+	})
 }
 
 func (h *Handler) UpdateUser(c echo.Context) error {
@@ -85,6 +284,19 @@ func (h *Handler) UpdateUser(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "User ID is required"})
 	}
 
+	requesterID, ok := auth.UserIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "authentication required"})
+	}
+
+	allowed, err := h.repo.IsOwnerOrAdmin(id, requesterID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to update user"})
+	}
+	if !allowed {
+		return c.JSON(http.StatusForbidden, map[string]string{"error": "forbidden"})
+	}
+
 	var req CreateUserRequest
 	if err := decodeStrictJSON(c, &req); err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request body"})
@@ -108,6 +320,19 @@ func (h *Handler) DeleteUser(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "User ID is required"})
 	}
 
+	requesterID, ok := auth.UserIDFromContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "authentication required"})
+	}
+
+	allowed, err := h.repo.IsOwnerOrAdmin(id, requesterID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to delete user"})
+	}
+	if !allowed {
+		return c.JSON(http.StatusForbidden, map[string]string{"error": "forbidden"})
+	}
+
 	if err := h.repo.Delete(id); err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to delete user"})
 	}
@@ -2,7 +2,11 @@ package user
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -20,17 +24,16 @@ func (r *Repository) Create(user CreateUserRequest) (*UserResponse, error) {
 	id := uuid.NewString()
 	
 	query := `
-		INSERT INTO users (id, username, email, age) 
-		VALUES ($1, $2, $3, $4) 
+		INSERT INTO users (id, username, email, age)
+		VALUES ($1, $2, $3, $4)
 		RETURNING id, username, email, age, created_at
 	`
-	
+
 	var response UserResponse
-	var createdAt interface{} // Ignore created_at for now
-	
+
 	err := r.db.QueryRow(context.Background(), query, id, user.Username, user.Email, user.Age).
-		Scan(&response.ID, &response.Username, &response.Email, &response.Age, &createdAt)
-	
+		Scan(&response.ID, &response.Username, &response.Email, &response.Age, &response.CreatedAt)
+
 	if err != nil {
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
@@ -39,85 +42,314 @@ func (r *Repository) Create(user CreateUserRequest) (*UserResponse, error) {
 }
 
 func (r *Repository) GetByID(id string) (*UserResponse, error) {
-	query := `SELECT id, username, email, age FROM users WHERE id = $1`
-	
+	query := `SELECT id, username, email, age, created_at FROM users WHERE id = $1`
+
 	var user UserResponse
 	err := r.db.QueryRow(context.Background(), query, id).
-		Scan(&user.ID, &user.Username, &user.Email, &user.Age)
-	
+		Scan(&user.ID, &user.Username, &user.Email, &user.Age, &user.CreatedAt)
+
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user by ID: %w", err)
 	}
-	
+
 	return &user, nil
 }
 
 func (r *Repository) GetByEmail(email string) (*UserResponse, error) {
-	query := `SELECT id, username, email, age FROM users WHERE email = $1`
-	
+	query := `SELECT id, username, email, age, created_at FROM users WHERE email = $1`
+
 	var user UserResponse
 	err := r.db.QueryRow(context.Background(), query, email).
-		Scan(&user.ID, &user.Username, &user.Email, &user.Age)
-	
+		Scan(&user.ID, &user.Username, &user.Email, &user.Age, &user.CreatedAt)
+
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user by email: %w", err)
 	}
-	
+
 	return &user, nil
 }
 
-func (r *Repository) GetAll() ([]UserResponse, error) {
-	query := `SELECT id, username, email, age FROM users ORDER BY created_at DESC`
-	
-	rows, err := r.db.Query(context.Background(), query)
+// sortableColumns whitelists the columns GET /users may sort by, since
+// the column name is interpolated into the query and cannot be
+// parameterized like a value.
+var sortableColumns = map[string]string{
+	"username":   "username",
+	"created_at": "created_at",
+	"age":        "age",
+}
+
+// ListParams controls pagination, filtering, and sorting for List.
+type ListParams struct {
+	Limit     int
+	Offset    int
+	Cursor    string
+	SortBy    string
+	Order     string
+	EmailLike string
+	MinAge    *int
+	MaxAge    *int
+	WithTotal bool
+}
+
+// ListResult is a page of users plus the metadata needed to fetch the next one.
+type ListResult struct {
+	Users      []UserResponse
+	Total      *int
+	NextCursor string
+}
+
+// cursorPayload is the decoded form of a base64 (created_at, id) cursor.
+type cursorPayload struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        string    `json:"id"`
+}
+
+func encodeCursor(createdAt time.Time, id string) string {
+	raw, _ := json.Marshal(cursorPayload{CreatedAt: createdAt, ID: id})
+	return base64.URLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(value string) (*cursorPayload, error) {
+	raw, err := base64.URLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	var payload cursorPayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	return &payload, nil
+}
+
+// List returns a page of users matching the given filters. When params.Cursor
+// is set it takes precedence over Offset and pagination walks rows in
+// (created_at, id) descending order so new inserts can't shift the page.
+func (r *Repository) List(params ListParams) (*ListResult, error) {
+	var conditions []string
+	var filterArgs []interface{}
+
+	if params.EmailLike != "" {
+		filterArgs = append(filterArgs, "%"+params.EmailLike+"%")
+		conditions = append(conditions, fmt.Sprintf("email ILIKE $%d", len(filterArgs)))
+	}
+	if params.MinAge != nil {
+		filterArgs = append(filterArgs, *params.MinAge)
+		conditions = append(conditions, fmt.Sprintf("age >= $%d", len(filterArgs)))
+	}
+	if params.MaxAge != nil {
+		filterArgs = append(filterArgs, *params.MaxAge)
+		conditions = append(conditions, fmt.Sprintf("age <= $%d", len(filterArgs)))
+	}
+
+	filterWhere := ""
+	if len(conditions) > 0 {
+		filterWhere = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	args := append([]interface{}{}, filterArgs...)
+
+	var cursor *cursorPayload
+	if params.Cursor != "" {
+		c, err := decodeCursor(params.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		cursor = c
+		args = append(args, cursor.CreatedAt, cursor.ID)
+		conditions = append(conditions, fmt.Sprintf("(created_at, id) < ($%d, $%d)", len(args)-1, len(args)))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	orderClause := "ORDER BY created_at DESC, id DESC"
+	if cursor == nil {
+		sortColumn, ok := sortableColumns[params.SortBy]
+		if !ok {
+			sortColumn = "created_at"
+		}
+		order := "DESC"
+		if strings.EqualFold(params.Order, "asc") {
+			order = "ASC"
+		}
+		orderClause = fmt.Sprintf("ORDER BY %s %s", sortColumn, order)
+	}
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	// Fetch one extra row so we can tell whether another page actually
+	// exists instead of assuming one whenever len(users) == limit.
+	args = append(args, limit+1)
+	limitClause := fmt.Sprintf("LIMIT $%d", len(args))
+
+	offsetClause := ""
+	if cursor == nil && params.Offset > 0 {
+		args = append(args, params.Offset)
+		offsetClause = fmt.Sprintf("OFFSET $%d", len(args))
+	}
+
+	query := fmt.Sprintf(
+		"SELECT id, username, email, age, created_at FROM users %s %s %s %s",
+		where, orderClause, limitClause, offsetClause,
+	)
+
+	rows, err := r.db.Query(context.Background(), query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get users: %w", err)
 	}
 	defer rows.Close()
-	
+
 	var users []UserResponse
 	for rows.Next() {
 		var user UserResponse
-		if err := rows.Scan(&user.ID, &user.Username, &user.Email, &user.Age); err != nil {
+		if err := rows.Scan(&user.ID, &user.Username, &user.Email, &user.Age, &user.CreatedAt); err != nil {
 			return nil, fmt.Errorf("failed to scan user: %w", err)
 		}
 		users = append(users, user)
 	}
-	
-	return users, nil
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read users: %w", err)
+	}
+
+	hasMore := len(users) > limit
+	if hasMore {
+		users = users[:limit]
+	}
+
+	result := &ListResult{Users: users}
+	if hasMore {
+		last := users[len(users)-1]
+		result.NextCursor = encodeCursor(last.CreatedAt, last.ID)
+	}
+
+	if params.WithTotal {
+		countQuery := fmt.Sprintf("SELECT COUNT(*) FROM users %s", filterWhere)
+		var total int
+		if err := r.db.QueryRow(context.Background(), countQuery, filterArgs...).Scan(&total); err != nil {
+			return nil, fmt.Errorf("failed to count users: %w", err)
+		}
+		result.Total = &total
+	}
+
+	return result, nil
 }
 
 func (r *Repository) Update(id string, user CreateUserRequest) (*UserResponse, error) {
 	query := `
-		UPDATE users 
+		UPDATE users
 		SET username = $2, email = $3, age = $4, updated_at = NOW()
-		WHERE id = $1 
-		RETURNING id, username, email, age
+		WHERE id = $1
+		RETURNING id, username, email, age, created_at
 	`
-	
+
 	var response UserResponse
 	err := r.db.QueryRow(context.Background(), query, id, user.Username, user.Email, user.Age).
-		Scan(&response.ID, &response.Username, &response.Email, &response.Age)
-	
+		Scan(&response.ID, &response.Username, &response.Email, &response.Age, &response.CreatedAt)
+
 	if err != nil {
 		return nil, fmt.Errorf("failed to update user: %w", err)
 	}
-	
+
 	return &response, nil
 }
 
 func (r *Repository) Delete(id string) error {
 	query := `DELETE FROM users WHERE id = $1`
-	
+
 	result, err := r.db.Exec(context.Background(), query, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete user: %w", err)
 	}
-	
+
 	rowsAffected := result.RowsAffected()
 	if rowsAffected == 0 {
 		return fmt.Errorf("user not found")
 	}
-	
+
 	return nil
+}
+
+// AuthUser carries the credential fields needed to authenticate a login
+// attempt; it is never serialized back to clients.
+type AuthUser struct {
+	ID           string
+	PasswordHash string
+	APIToken     string
+	IsAdmin      bool
+}
+
+// CreateWithAuth inserts a user along with their bcrypt password hash and
+// generated API token, as produced during registration.
+func (r *Repository) CreateWithAuth(req RegisterRequest, passwordHash, apiToken string) (*UserResponse, error) {
+	id := uuid.NewString()
+
+	query := `
+		INSERT INTO users (id, username, email, age, password_hash, api_token)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, username, email, age, created_at
+	`
+
+	var response UserResponse
+	err := r.db.QueryRow(context.Background(), query, id, req.Username, req.Email, req.Age, passwordHash, apiToken).
+		Scan(&response.ID, &response.Username, &response.Email, &response.Age, &response.CreatedAt)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	return &response, nil
+}
+
+// GetAuthByEmail fetches the credential fields needed to verify a login.
+func (r *Repository) GetAuthByEmail(email string) (*AuthUser, error) {
+	query := `SELECT id, password_hash, api_token, is_admin FROM users WHERE email = $1`
+
+	var authUser AuthUser
+	err := r.db.QueryRow(context.Background(), query, email).
+		Scan(&authUser.ID, &authUser.PasswordHash, &authUser.APIToken, &authUser.IsAdmin)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user by email: %w", err)
+	}
+
+	return &authUser, nil
+}
+
+// GetUserIDByAPIToken resolves a per-user API token (sent via the
+// X-API-Key header) to the id of the user it belongs to.
+func (r *Repository) GetUserIDByAPIToken(token string) (string, error) {
+	query := `SELECT id FROM users WHERE api_token = $1`
+
+	var id string
+	err := r.db.QueryRow(context.Background(), query, token).Scan(&id)
+	if err != nil {
+		return "", fmt.Errorf("failed to get user by api token: %w", err)
+	}
+
+	return id, nil
+}
+
+// IsOwnerOrAdmin reports whether requesterID may act on the user with the
+// given id: either they are the same user, or requesterID belongs to an
+// admin.
+func (r *Repository) IsOwnerOrAdmin(id, requesterID string) (bool, error) {
+	if id == requesterID {
+		return true, nil
+	}
+
+	query := `SELECT is_admin FROM users WHERE id = $1`
+
+	var isAdmin bool
+	err := r.db.QueryRow(context.Background(), query, requesterID).Scan(&isAdmin)
+	if err != nil {
+		return false, fmt.Errorf("failed to check admin status: %w", err)
+	}
+
+	return isAdmin, nil
 }
\ No newline at end of file
@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+const contextUserIDKey = "auth_user_id"
+
+// APITokenLookup resolves a raw per-user API token (as issued by
+// GenerateAPIToken) to the id of the user it belongs to.
+type APITokenLookup func(token string) (userID string, err error)
+
+// Middleware authenticates a request via either an "X-API-Key: <token>"
+// header or an "Authorization: Bearer <jwt>" header, and injects the
+// authenticated user id into the echo.Context for downstream handlers.
+// lookup resolves X-API-Key values; it may be nil to accept JWTs only.
+func Middleware(lookup APITokenLookup) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if apiKey := c.Request().Header.Get("X-API-Key"); apiKey != "" {
+				if lookup == nil {
+					return c.JSON(http.StatusUnauthorized, map[string]string{"error": "api key authentication is not available"})
+				}
+
+				userID, err := lookup(apiKey)
+				if err != nil {
+					return c.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid api key"})
+				}
+
+				c.Set(contextUserIDKey, userID)
+				return next(c)
+			}
+
+			header := c.Request().Header.Get("Authorization")
+			if !strings.HasPrefix(header, "Bearer ") {
+				return c.JSON(http.StatusUnauthorized, map[string]string{"error": "missing or invalid authorization header"})
+			}
+
+			claims, err := ParseJWT(strings.TrimPrefix(header, "Bearer "))
+			if err != nil {
+				return c.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid or expired token"})
+			}
+
+			c.Set(contextUserIDKey, claims.UserID)
+			return next(c)
+		}
+	}
+}
+
+// UserIDFromContext returns the authenticated user id set by Middleware.
+func UserIDFromContext(c echo.Context) (string, bool) {
+	id, ok := c.Get(contextUserIDKey).(string)
+	return id, ok
+}
@@ -0,0 +1,99 @@
+package config
+
+// Config is the fully resolved application configuration, after merging
+// the base file, the environment-specific overlay, and environment
+// variables.
+type Config struct {
+	DB       DBConfig     `toml:"db" json:"db"`
+	HTTP     HTTPConfig   `toml:"http" json:"http"`
+	JWT      JWTConfig    `toml:"jwt" json:"jwt"`
+	Log      LogConfig    `toml:"log" json:"log"`
+	Features FeatureFlags `toml:"features" json:"features"`
+}
+
+type DBConfig struct {
+	DSN         string `toml:"dsn" json:"dsn"`
+	MaxPoolSize int    `toml:"max_pool_size" json:"max_pool_size"`
+	MinPoolSize int    `toml:"min_pool_size" json:"min_pool_size"`
+}
+
+type HTTPConfig struct {
+	Port int `toml:"port" json:"port"`
+}
+
+type JWTConfig struct {
+	Secret string `toml:"secret" json:"secret"`
+}
+
+type LogConfig struct {
+	Level string `toml:"level" json:"level"`
+}
+
+type FeatureFlags struct {
+	AutoMigrate bool `toml:"auto_migrate" json:"auto_migrate"`
+}
+
+// Overlay is a partial Config where every field is a pointer. A nil field
+// means "not set by this layer" so merging an Overlay onto a base Config
+// only touches the fields it actually sets, following the merge semantics
+// used by the Supabase CLI's config overrides.
+type Overlay struct {
+	DB       *DBOverlay      `toml:"db" json:"db"`
+	HTTP     *HTTPOverlay    `toml:"http" json:"http"`
+	JWT      *JWTOverlay     `toml:"jwt" json:"jwt"`
+	Log      *LogOverlay     `toml:"log" json:"log"`
+	Features *FeatureOverlay `toml:"features" json:"features"`
+}
+
+type DBOverlay struct {
+	DSN         *string `toml:"dsn" json:"dsn"`
+	MaxPoolSize *int    `toml:"max_pool_size" json:"max_pool_size"`
+	MinPoolSize *int    `toml:"min_pool_size" json:"min_pool_size"`
+}
+
+type HTTPOverlay struct {
+	Port *int `toml:"port" json:"port"`
+}
+
+type JWTOverlay struct {
+	Secret *string `toml:"secret" json:"secret"`
+}
+
+type LogOverlay struct {
+	Level *string `toml:"level" json:"level"`
+}
+
+type FeatureOverlay struct {
+	AutoMigrate *bool `toml:"auto_migrate" json:"auto_migrate"`
+}
+
+// Merge applies every non-nil field of o onto cfg.
+func (o Overlay) Merge(cfg *Config) {
+	if o.DB != nil {
+		if o.DB.DSN != nil {
+			cfg.DB.DSN = *o.DB.DSN
+		}
+		if o.DB.MaxPoolSize != nil {
+			cfg.DB.MaxPoolSize = *o.DB.MaxPoolSize
+		}
+		if o.DB.MinPoolSize != nil {
+			cfg.DB.MinPoolSize = *o.DB.MinPoolSize
+		}
+	}
+
+	if o.HTTP != nil && o.HTTP.Port != nil {
+		cfg.HTTP.Port = *o.HTTP.Port
+	}
+
+	if o.JWT != nil && o.JWT.Secret != nil {
+		cfg.JWT.Secret = *o.JWT.Secret
+	}
+
+	if o.Log != nil && o.Log.Level != nil {
+		cfg.Log.Level = *o.Log.Level
+	}
+
+	if o.Features != nil && o.Features.AutoMigrate != nil {
+		cfg.Features.AutoMigrate = *o.Features.AutoMigrate
+	}
+}
@@ -0,0 +1,127 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// defaults returns the Config used when neither a base file nor env
+// variables override a field.
+func defaults() Config {
+	return Config{
+		DB: DBConfig{
+			MaxPoolSize: 10,
+			MinPoolSize: 1,
+		},
+		HTTP: HTTPConfig{
+			Port: 8080,
+		},
+		Log: LogConfig{
+			Level: "info",
+		},
+	}
+}
+
+// Load builds the application Config by starting from defaults, merging
+// basePath (required), then an environment-specific overlay next to
+// basePath with the same extension (e.g. "config.dev.toml" for
+// "config.toml" and appEnv "dev") if present, and finally applying
+// environment variable overrides.
+func Load(basePath, appEnv string) (*Config, error) {
+	cfg := defaults()
+
+	if err := decodeFileInto(basePath, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to load base config %s: %w", basePath, err)
+	}
+
+	if appEnv != "" {
+		overlayPath := overlayPathFor(basePath, appEnv)
+		if _, err := os.Stat(overlayPath); err == nil {
+			var overlay Overlay
+			if err := decodeFileInto(overlayPath, &overlay); err != nil {
+				return nil, fmt.Errorf("failed to load config overlay %s: %w", overlayPath, err)
+			}
+			overlay.Merge(&cfg)
+		}
+	}
+
+	applyEnvOverrides(&cfg)
+
+	return &cfg, nil
+}
+
+// overlayPathFor inserts appEnv before basePath's extension,
+// e.g. "config.toml" + "dev" -> "config.dev.toml".
+func overlayPathFor(basePath, appEnv string) string {
+	ext := filepath.Ext(basePath)
+	stem := strings.TrimSuffix(basePath, ext)
+	return fmt.Sprintf("%s.%s%s", stem, appEnv, ext)
+}
+
+// decodeFileInto decodes a TOML or JSON file (chosen by extension) into v.
+func decodeFileInto(path string, v interface{}) error {
+	switch filepath.Ext(path) {
+	case ".json":
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(data, v)
+	default:
+		_, err := toml.DecodeFile(path, v)
+		return err
+	}
+}
+
+// applyEnvOverrides lets environment variables win over both the base file
+// and the overlay, matching the precedence env.LoadEnv already uses for
+// .env files.
+func applyEnvOverrides(cfg *Config) {
+	if v := os.Getenv("DATABASE_URL"); v != "" {
+		cfg.DB.DSN = v
+	}
+	if v, ok := envInt("DB_MAX_POOL_SIZE"); ok {
+		cfg.DB.MaxPoolSize = v
+	}
+	if v, ok := envInt("DB_MIN_POOL_SIZE"); ok {
+		cfg.DB.MinPoolSize = v
+	}
+	if v, ok := envInt("HTTP_PORT"); ok {
+		cfg.HTTP.Port = v
+	}
+	if v := os.Getenv("JWT_SECRET"); v != "" {
+		cfg.JWT.Secret = v
+	}
+	if v := os.Getenv("LOG_LEVEL"); v != "" {
+		cfg.Log.Level = v
+	}
+	if v, ok := envBool("AUTO_MIGRATE"); ok {
+		cfg.Features.AutoMigrate = v
+	}
+}
+
+func envInt(key string) (int, bool) {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return 0, false
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
+
+func envBool(key string) (bool, bool) {
+	raw := strings.ToLower(os.Getenv(key))
+	if raw == "" {
+		return false, false
+	}
+	return raw == "true" || raw == "1" || raw == "yes", true
+}
@@ -5,88 +5,404 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 )
 
-func RunMigrations() error {
-	if DB == nil {
-		return fmt.Errorf("database not initialized")
+// migrationDir is the directory scanned for numbered up/down SQL pairs,
+// e.g. "001_create_users.up.sql" / "001_create_users.down.sql".
+const migrationDir = "migrations"
+
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// Migration describes a single numbered schema change, backed by an
+// up file (required) and an optional down file for rollback.
+type Migration struct {
+	Version  int
+	Name     string
+	UpFile   string
+	DownFile string
+}
+
+// MigrationStatus reports whether a discovered migration has been applied.
+type MigrationStatus struct {
+	Version int
+	Name    string
+	Applied bool
+}
+
+// ProgressReporter receives progress updates as RunMigrations or MigrateTo
+// step through pending migrations, one Step call per migration about to run.
+type ProgressReporter interface {
+	Step(current, total int, name string)
+}
+
+func reportStep(reporter ProgressReporter, current, total int, name string) {
+	if reporter != nil {
+		reporter.Step(current, total, name)
+	}
+}
+
+// loadMigrations scans migrationDir and groups up/down files by version,
+// returning them sorted in ascending version order.
+func loadMigrations() ([]Migration, error) {
+	entries, err := os.ReadDir(migrationDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations directory: %w", err)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+
+		version, err := strconv.Atoi(match[1])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse migration version from %s: %w", entry.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+
+		switch match[3] {
+		case "up":
+			m.UpFile = entry.Name()
+		case "down":
+			m.DownFile = entry.Name()
+		}
 	}
 
-	// Create migrations table if it doesn't exist
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.UpFile == "" {
+			return nil, fmt.Errorf("migration %d (%s) is missing its .up.sql file", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// ensureMigrationsTable creates the migrations tracking table if needed.
+func ensureMigrationsTable() error {
 	createMigrationsTable := `
 		CREATE TABLE IF NOT EXISTS migrations (
 			id SERIAL PRIMARY KEY,
-			filename VARCHAR(255) NOT NULL UNIQUE,
+			version INTEGER NOT NULL UNIQUE,
+			filename VARCHAR(255) NOT NULL,
 			executed_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
 		);
 	`
-	
+
 	if _, err := DB.Exec(context.Background(), createMigrationsTable); err != nil {
 		return fmt.Errorf("failed to create migrations table: %w", err)
 	}
 
-	// Get migration files
-	migrationDir := "migrations"
-	files, err := os.ReadDir(migrationDir)
+	return nil
+}
+
+// appliedVersions returns the set of migration versions already recorded,
+// along with the highest applied version (0 if none).
+func appliedVersions() (map[int]bool, int, error) {
+	rows, err := DB.Query(context.Background(), "SELECT version FROM migrations")
 	if err != nil {
-		return fmt.Errorf("failed to read migrations directory: %w", err)
+		return nil, 0, fmt.Errorf("failed to query executed migrations: %w", err)
 	}
+	defer rows.Close()
 
-	// Filter and sort SQL files
-	var sqlFiles []string
-	for _, file := range files {
-		if !file.IsDir() && strings.HasSuffix(file.Name(), ".sql") {
-			sqlFiles = append(sqlFiles, file.Name())
+	applied := make(map[int]bool)
+	latest := 0
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan migration version: %w", err)
+		}
+		applied[version] = true
+		if version > latest {
+			latest = version
 		}
 	}
-	sort.Strings(sqlFiles)
 
-	// Check which migrations have been executed
-	executedMigrations := make(map[string]bool)
-	rows, err := DB.Query(context.Background(), "SELECT filename FROM migrations")
+	return applied, latest, rows.Err()
+}
+
+// applyUp runs a single migration's up file inside a transaction and
+// records it in the migrations table.
+func applyUp(m Migration) error {
+	content, err := os.ReadFile(filepath.Join(migrationDir, m.UpFile))
 	if err != nil {
-		return fmt.Errorf("failed to query executed migrations: %w", err)
+		return fmt.Errorf("failed to read migration file %s: %w", m.UpFile, err)
 	}
-	defer rows.Close()
 
-	for rows.Next() {
-		var filename string
-		if err := rows.Scan(&filename); err != nil {
-			return fmt.Errorf("failed to scan migration filename: %w", err)
-		}
-		executedMigrations[filename] = true
+	ctx := context.Background()
+	tx, err := DB.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction for migration %s: %w", m.UpFile, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, string(content)); err != nil {
+		return fmt.Errorf("failed to execute migration %s: %w", m.UpFile, err)
+	}
+
+	if _, err := tx.Exec(ctx, "INSERT INTO migrations (version, filename) VALUES ($1, $2)", m.Version, m.UpFile); err != nil {
+		return fmt.Errorf("failed to record migration %s: %w", m.UpFile, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit migration %s: %w", m.UpFile, err)
+	}
+
+	fmt.Printf("Migration %s executed successfully\n", m.UpFile)
+	return nil
+}
+
+// applyDown runs a single migration's down file inside a transaction and
+// removes its row from the migrations table.
+func applyDown(m Migration) error {
+	if m.DownFile == "" {
+		return fmt.Errorf("migration %d (%s) has no .down.sql file", m.Version, m.Name)
+	}
+
+	content, err := os.ReadFile(filepath.Join(migrationDir, m.DownFile))
+	if err != nil {
+		return fmt.Errorf("failed to read migration file %s: %w", m.DownFile, err)
 	}
 
-	// Execute pending migrations
-	for _, filename := range sqlFiles {
-		if executedMigrations[filename] {
-			fmt.Printf("Migration %s already executed, skipping\n", filename)
+	ctx := context.Background()
+	tx, err := DB.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction for migration %s: %w", m.DownFile, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, string(content)); err != nil {
+		return fmt.Errorf("failed to execute migration %s: %w", m.DownFile, err)
+	}
+
+	if _, err := tx.Exec(ctx, "DELETE FROM migrations WHERE version = $1", m.Version); err != nil {
+		return fmt.Errorf("failed to remove migration record %d: %w", m.Version, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit rollback %s: %w", m.DownFile, err)
+	}
+
+	fmt.Printf("Migration %s rolled back successfully\n", m.DownFile)
+	return nil
+}
+
+// RunMigrations applies all pending migrations in ascending version order.
+// ctx is checked between migrations (never mid-transaction), so a
+// cancelled context still leaves the current migration's transaction
+// either fully committed or fully rolled back; reporter may be nil.
+func RunMigrations(ctx context.Context, reporter ProgressReporter) error {
+	if DB == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	if err := ensureMigrationsTable(); err != nil {
+		return err
+	}
+
+	migrations, err := loadMigrations()
+	if err != nil {
+		return err
+	}
+
+	applied, latest, err := appliedVersions()
+	if err != nil {
+		return err
+	}
+
+	var pending []Migration
+	for _, m := range migrations {
+		if applied[m.Version] {
+			fmt.Printf("Migration %s already executed, skipping\n", m.UpFile)
 			continue
 		}
+		pending = append(pending, m)
+	}
 
-		fmt.Printf("Executing migration %s\n", filename)
-		
-		// Read migration file
-		content, err := os.ReadFile(filepath.Join(migrationDir, filename))
-		if err != nil {
-			return fmt.Errorf("failed to read migration file %s: %w", filename, err)
+	lastApplied := latest
+	for i, m := range pending {
+		select {
+		case <-ctx.Done():
+			fmt.Printf("Migration interrupted; last successfully applied version is %d\n", lastApplied)
+			return ctx.Err()
+		default:
 		}
 
-		// Execute migration
-		if _, err := DB.Exec(context.Background(), string(content)); err != nil {
-			return fmt.Errorf("failed to execute migration %s: %w", filename, err)
+		reportStep(reporter, i+1, len(pending), m.Name)
+
+		if err := applyUp(m); err != nil {
+			return err
 		}
+		lastApplied = m.Version
+	}
+
+	return nil
+}
+
+// RunMigrationsDown rolls back the most-recently applied migration.
+func RunMigrationsDown() error {
+	if DB == nil {
+		return fmt.Errorf("database not initialized")
+	}
 
-		// Record migration as executed
-		if _, err := DB.Exec(context.Background(), 
-			"INSERT INTO migrations (filename) VALUES ($1)", filename); err != nil {
-			return fmt.Errorf("failed to record migration %s: %w", filename, err)
+	if err := ensureMigrationsTable(); err != nil {
+		return err
+	}
+
+	migrations, err := loadMigrations()
+	if err != nil {
+		return err
+	}
+
+	_, latest, err := appliedVersions()
+	if err != nil {
+		return err
+	}
+
+	if latest == 0 {
+		fmt.Println("No applied migrations to roll back")
+		return nil
+	}
+
+	for _, m := range migrations {
+		if m.Version == latest {
+			return applyDown(m)
 		}
+	}
+
+	return fmt.Errorf("no migration file found for applied version %d", latest)
+}
+
+// MigrateTo brings the schema to exactly the given version, running
+// pending up migrations if version is ahead of the current state, or
+// down migrations (in reverse order) if it is behind. ctx is checked
+// between steps, same as RunMigrations; reporter may be nil.
+func MigrateTo(ctx context.Context, version int, reporter ProgressReporter) error {
+	if DB == nil {
+		return fmt.Errorf("database not initialized")
+	}
 
-		fmt.Printf("Migration %s executed successfully\n", filename)
+	if err := ensureMigrationsTable(); err != nil {
+		return err
 	}
 
+	migrations, err := loadMigrations()
+	if err != nil {
+		return err
+	}
+
+	applied, latest, err := appliedVersions()
+	if err != nil {
+		return err
+	}
+
+	if version > latest {
+		var pending []Migration
+		for _, m := range migrations {
+			if m.Version <= latest || m.Version > version || applied[m.Version] {
+				continue
+			}
+			pending = append(pending, m)
+		}
+
+		lastApplied := latest
+		for i, m := range pending {
+			select {
+			case <-ctx.Done():
+				fmt.Printf("Migration interrupted; last successfully applied version is %d\n", lastApplied)
+				return ctx.Err()
+			default:
+			}
+
+			reportStep(reporter, i+1, len(pending), m.Name)
+
+			if err := applyUp(m); err != nil {
+				return err
+			}
+			lastApplied = m.Version
+		}
+		return nil
+	}
+
+	if version < latest {
+		var pending []Migration
+		for i := len(migrations) - 1; i >= 0; i-- {
+			m := migrations[i]
+			if m.Version <= version || m.Version > latest || !applied[m.Version] {
+				continue
+			}
+			pending = append(pending, m)
+		}
+
+		lastApplied := latest
+		for i, m := range pending {
+			select {
+			case <-ctx.Done():
+				fmt.Printf("Migration interrupted; last successfully applied version is %d\n", lastApplied)
+				return ctx.Err()
+			default:
+			}
+
+			reportStep(reporter, i+1, len(pending), m.Name)
+
+			if err := applyDown(m); err != nil {
+				return err
+			}
+			lastApplied = m.Version - 1
+		}
+		return nil
+	}
+
+	fmt.Printf("Already at version %d\n", version)
 	return nil
-}
\ No newline at end of file
+}
+
+// Status reports, for every discovered migration, whether it has been
+// applied to the database.
+func Status() ([]MigrationStatus, error) {
+	if DB == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	if err := ensureMigrationsTable(); err != nil {
+		return nil, err
+	}
+
+	migrations, err := loadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	applied, _, err := appliedVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(migrations))
+	for _, m := range migrations {
+		statuses = append(statuses, MigrationStatus{
+			Version: m.Version,
+			Name:    strings.ReplaceAll(m.Name, "_", " "),
+			Applied: applied[m.Version],
+		})
+	}
+
+	return statuses, nil
+}
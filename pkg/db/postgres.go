@@ -3,25 +3,38 @@ package db
 import (
 	"context"
 	"fmt"
-	"os"
+	"log"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/phuocnguyen/user-api/pkg/config"
 )
 
 var DB *pgxpool.Pool
 
-func InitPostgres() error {
-	dsn := os.Getenv("DATABASE_URL")
-	println("Connecting to PostgreSQL with DSN:", dsn)
-	if dsn == "" {
-		return fmt.Errorf("DATABASE_URL environment variable is not set")
+// InitPostgres opens the pool DB uses for the lifetime of the process,
+// applying the DSN and pool size limits from cfg.
+func InitPostgres(cfg config.DBConfig) error {
+	if cfg.DSN == "" {
+		return fmt.Errorf("database DSN is not set")
 	}
+	log.Printf("Connecting to PostgreSQL")
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	pool, err := pgxpool.New(ctx, dsn)
+	poolConfig, err := pgxpool.ParseConfig(cfg.DSN)
+	if err != nil {
+		return fmt.Errorf("failed to parse PostgreSQL DSN: %w", err)
+	}
+	if cfg.MaxPoolSize > 0 {
+		poolConfig.MaxConns = int32(cfg.MaxPoolSize)
+	}
+	if cfg.MinPoolSize > 0 {
+		poolConfig.MinConns = int32(cfg.MinPoolSize)
+	}
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
 	if err != nil {
 		return fmt.Errorf("failed to connect to PostgreSQL: %w", err)
 	}
@@ -15,37 +15,58 @@ func LoadEnv(filename string) error {
 	}
 	defer file.Close()
 
+	loaded := make(map[string]string)
+
 	scanner := bufio.NewScanner(file)
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
-		
+
 		// Skip empty lines and comments
 		if len(line) == 0 || strings.HasPrefix(line, "#") {
 			continue
 		}
-		
+
+		// Allow shell-style "export KEY=VALUE" lines
+		line = strings.TrimPrefix(line, "export ")
+		line = strings.TrimSpace(line)
+
 		// Split key=value
 		parts := strings.SplitN(line, "=", 2)
 		if len(parts) != 2 {
 			continue
 		}
-		
+
 		key := strings.TrimSpace(parts[0])
 		value := strings.TrimSpace(parts[1])
-		
+
+		singleQuoted := strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'") && len(value) >= 2
+
 		// Remove quotes if present
-		if len(value) >= 2 {
-			if (strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"")) ||
-				(strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'")) {
-				value = value[1 : len(value)-1]
-			}
+		quoted := len(value) >= 2 &&
+			((strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"")) ||
+				singleQuoted)
+		if quoted {
+			value = value[1 : len(value)-1]
+		}
+
+		// Expand ${VAR} references against already-loaded keys and the
+		// process environment, unless the value was single-quoted.
+		if !singleQuoted {
+			value = os.Expand(value, func(ref string) string {
+				if v, ok := loaded[ref]; ok {
+					return v
+				}
+				return os.Getenv(ref)
+			})
 		}
-		
+
+		loaded[key] = value
+
 		// Only set if not already set (environment variables take precedence)
 		if os.Getenv(key) == "" {
 			os.Setenv(key, value)
 		}
 	}
-	
+
 	return scanner.Err()
-}
\ No newline at end of file
+}
@@ -3,10 +3,12 @@ package httpxecho
 import (
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/phuocnguyen/user-api/internal/job"
 	"github.com/phuocnguyen/user-api/internal/user"
+	"github.com/phuocnguyen/user-api/pkg/auth"
 )
 
-func RegisterRoutes(e *echo.Echo, userHandler *user.Handler) {
+func RegisterRoutes(e *echo.Echo, userHandler *user.Handler, jobHandler *job.Handler) {
 	// Middleware
 	e.Use(middleware.Logger())
 	e.Use(middleware.Recover())
@@ -15,11 +17,21 @@ func RegisterRoutes(e *echo.Echo, userHandler *user.Handler) {
 	// API routes
 	api := e.Group("/api/v1")
 
+	// Auth routes
+	authGroup := api.Group("/auth")
+	authGroup.POST("/register", userHandler.Register)
+	authGroup.POST("/login", userHandler.Login)
+
 	// User routes
 	users := api.Group("/users")
 	users.POST("", userHandler.CreateUser)
 	users.GET("", userHandler.GetUsers)
 	users.GET("/:id", userHandler.GetUser)
-	users.PUT("/:id", userHandler.UpdateUser)
-	users.DELETE("/:id", userHandler.DeleteUser)
+	authMiddleware := auth.Middleware(userHandler.LookupUserIDByAPIToken)
+	users.PUT("/:id", userHandler.UpdateUser, authMiddleware)
+	users.DELETE("/:id", userHandler.DeleteUser, authMiddleware)
+
+	// Job routes
+	jobs := api.Group("/jobs")
+	jobs.GET("/:id", jobHandler.GetJob)
 }
\ No newline at end of file